@@ -0,0 +1,181 @@
+package vsop87
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+const (
+	cacheMagic   uint32 = 0x37385376 // "vS87" little-endian
+	cacheVersion uint32 = 1
+)
+
+// cacheFields lists the ellipticCoeff fields in the fixed order used by
+// WriteCache and LoadEllipticModelCache.
+var cacheFields = []func(*ellipticCoeff) *coeff{
+	func(c *ellipticCoeff) *coeff { return &c.a },
+	func(c *ellipticCoeff) *coeff { return &c.l },
+	func(c *ellipticCoeff) *coeff { return &c.k },
+	func(c *ellipticCoeff) *coeff { return &c.h },
+	func(c *ellipticCoeff) *coeff { return &c.q },
+	func(c *ellipticCoeff) *coeff { return &c.p },
+}
+
+// WriteCache serializes the parsed coefficients to w in a compact binary
+// form: a little-endian header (magic, version, body count, the prec and
+// tdj requested when em was built), a table of per-body per-time-power
+// term counts, and finally the packed a,b,c term triples themselves.
+//
+// The cache can be reloaded with LoadEllipticModelCache, skipping the text
+// parse in NewEllipticModel entirely.
+func (em *EllipticModel) WriteCache(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, v := range []interface{}{cacheMagic, cacheVersion, uint32(nBodies), em.prec, em.tdj} {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	for ibody := range em.c {
+		for _, field := range cacheFields {
+			c := field(&em.c[ibody])
+			for it := 0; it < 6; it++ {
+				if err := binary.Write(bw, binary.LittleEndian, uint32(len(c[it]))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for ibody := range em.c {
+		for _, field := range cacheFields {
+			c := field(&em.c[ibody])
+			for it := 0; it < 6; it++ {
+				for _, term := range c[it] {
+					if err := binary.Write(bw, binary.LittleEndian, term.a); err != nil {
+						return err
+					}
+					if err := binary.Write(bw, binary.LittleEndian, term.b); err != nil {
+						return err
+					}
+					if err := binary.Write(bw, binary.LittleEndian, term.c); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// LoadEllipticModelCache reads a cache written by WriteCache and returns
+// the reconstructed model, without parsing any VSOP87 text.
+func LoadEllipticModelCache(r io.Reader) (*EllipticModel, error) {
+	br := bufio.NewReader(r)
+	var magic, version, nb uint32
+	if err := binary.Read(br, binary.LittleEndian, &magic); err != nil {
+		return nil, err
+	}
+	if magic != cacheMagic {
+		return nil, errors.New("vsop87: not an elliptic model cache")
+	}
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != cacheVersion {
+		return nil, fmt.Errorf("vsop87: unsupported cache version %d", version)
+	}
+	if err := binary.Read(br, binary.LittleEndian, &nb); err != nil {
+		return nil, err
+	}
+	if nb != nBodies {
+		return nil, fmt.Errorf("vsop87: cache has %d bodies, want %d", nb, nBodies)
+	}
+	em := &EllipticModel{}
+	if err := binary.Read(br, binary.LittleEndian, &em.prec); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(br, binary.LittleEndian, &em.tdj); err != nil {
+		return nil, err
+	}
+
+	var lens [nBodies][6][6]uint32
+	for ibody := 0; ibody < nBodies; ibody++ {
+		for fi := 0; fi < 6; fi++ {
+			for it := 0; it < 6; it++ {
+				if err := binary.Read(br, binary.LittleEndian, &lens[ibody][fi][it]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for ibody := 0; ibody < nBodies; ibody++ {
+		for fi, field := range cacheFields {
+			c := field(&em.c[ibody])
+			for it := 0; it < 6; it++ {
+				n := int(lens[ibody][fi][it])
+				if n == 0 {
+					continue
+				}
+				terms := make([]abc, n)
+				for i := range terms {
+					var va, vb, vc float64
+					if err := binary.Read(br, binary.LittleEndian, &va); err != nil {
+						return nil, err
+					}
+					if err := binary.Read(br, binary.LittleEndian, &vb); err != nil {
+						return nil, err
+					}
+					if err := binary.Read(br, binary.LittleEndian, &vc); err != nil {
+						return nil, err
+					}
+					terms[i] = abc{va, vb, vc}
+				}
+				c[it] = terms
+			}
+		}
+	}
+	return em, nil
+}
+
+// NewEllipticModelAuto loads em from cachePath if it exists, was built
+// with precision at least as fine as prec, and was built for a tdj at
+// least as far from J2000 as the requested one (the term truncation in
+// coeff.parse grows looser closer to J2000, so a cache built nearer to
+// J2000 than the requested tdj may be missing terms the requested date
+// needs).  Otherwise it parses and caches from vsop87Path.  Pass an empty
+// cachePath to disable caching.
+func NewEllipticModelAuto(cachePath, vsop87Path string, prec, tdj float64) (*EllipticModel, error) {
+	if cachePath != "" {
+		if f, err := os.Open(cachePath); err == nil {
+			em, err := LoadEllipticModelCache(f)
+			f.Close()
+			if err == nil && em.prec <= prec && math.Abs(em.tdj-t2000) >= math.Abs(tdj-t2000) {
+				return em, nil
+			}
+		}
+	}
+	em, err := NewEllipticModel(DirSource(vsop87Path), prec, tdj)
+	if err != nil {
+		return nil, err
+	}
+	if cachePath != "" {
+		f, err := os.Create(cachePath)
+		if err != nil {
+			return nil, err
+		}
+		err = em.WriteCache(f)
+		cerr := f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if cerr != nil {
+			return nil, cerr
+		}
+	}
+	return em, nil
+}
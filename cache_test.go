@@ -0,0 +1,38 @@
+package vsop87_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/soniakeys/vsop87"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	fsys := fsWithBody(0, 6, 1, 1.25, vsop87.Mercury)
+	em, err := vsop87.NewEllipticModel(vsop87.FSSource(fsys), 1e-7, 2451545)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := em.WriteCache(&buf); err != nil {
+		t.Fatal(err)
+	}
+	em2, err := vsop87.LoadEllipticModelCache(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want, got vsop87.Elliptic
+	em.Pos(2451545, vsop87.Mercury, &want)
+	em2.Pos(2451545, vsop87.Mercury, &got)
+	if want != got {
+		t.Fatalf("cache round trip mismatch: want %+v, got %+v", want, got)
+	}
+}
+
+func TestLoadEllipticModelCacheBadMagic(t *testing.T) {
+	if _, err := vsop87.LoadEllipticModelCache(bytes.NewReader([]byte{1, 2, 3, 4})); err == nil {
+		t.Fatal("expected an error loading a non-cache stream")
+	}
+}
@@ -0,0 +1,72 @@
+//go:build validation
+
+// Command vsop87-validate cross-checks VSOP87 against a CSV or JSON dump
+// of reference ephemeris states (for example JPL DE440/DE440s, converted
+// to the VSOP87 ecliptic J2000 frame) and reports per-body position and
+// velocity error.  It exits with a non-zero status if any body exceeds
+// its tolerance.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/soniakeys/vsop87"
+	"github.com/soniakeys/vsop87/validate"
+)
+
+// t2000 is J2000, matching the unexported constant of the same name in
+// the vsop87 package.
+const t2000 = 2451545
+
+func main() {
+	vsop87Path := flag.String("vsop87", os.Getenv("VSOP87"), "path to VSOP87 elliptic coefficient files")
+	ref := flag.String("ref", "", "path to a CSV or JSON reference ephemeris dump")
+	prec := flag.Float64("prec", 0, "VSOP87 precision (radians/au), 0 for full precision")
+	flag.Parse()
+
+	if *vsop87Path == "" || *ref == "" {
+		fmt.Fprintln(os.Stderr, "usage: vsop87-validate -vsop87 path -ref states.csv")
+		os.Exit(2)
+	}
+
+	states, err := validate.LoadStates(*ref)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(states) == 0 {
+		fmt.Fprintln(os.Stderr, "no reference states loaded")
+		os.Exit(1)
+	}
+
+	// Build em for the state farthest from J2000, not just the first one:
+	// coeff.parse keeps fewer terms the closer tdj is to J2000, so building
+	// from an arbitrary near-J2000 state could truncate terms later states
+	// spanning centuries actually need.
+	tdj := states[0].JD
+	for _, s := range states[1:] {
+		if math.Abs(s.JD-t2000) > math.Abs(tdj-t2000) {
+			tdj = s.JD
+		}
+	}
+	em, err := vsop87.NewEllipticModel(vsop87.DirSource(*vsop87Path), *prec, tdj)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	reports := validate.Run(em, states, validate.DefaultTolerances)
+	fail := false
+	for _, r := range reports {
+		fmt.Println(r)
+		if !r.Pass {
+			fail = true
+		}
+	}
+	if fail {
+		os.Exit(1)
+	}
+}
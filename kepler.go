@@ -0,0 +1,119 @@
+package vsop87
+
+import "math"
+
+// GM is the heliocentric gravitational parameter, in au**3/day**2,
+// derived from the Gaussian gravitational constant k = 0.01720209895.
+const GM = 0.01720209895 * 0.01720209895
+
+// Rectangular converts the osculating elliptic elements to a heliocentric
+// ecliptic rectangular position and velocity in the VSOP87 frame, by
+// solving Kepler's equation for the eccentric anomaly.
+func (e *Elliptic) Rectangular() Rectangular {
+	px, py, pz, vx, vy, vz := e.stateVectors()
+	return Rectangular{px, py, pz, vx, vy, vz}
+}
+
+// Spherical converts the osculating elliptic elements to a heliocentric
+// ecliptic spherical position and velocity in the VSOP87 frame, by way of
+// Rectangular.
+func (e *Elliptic) Spherical() Spherical {
+	r := e.Rectangular()
+	return r.Spherical()
+}
+
+// stateVectors does the actual element-to-state-vector conversion used by
+// Rectangular.
+func (e *Elliptic) stateVectors() (px, py, pz, vx, vy, vz float64) {
+	ecc := math.Hypot(e.K, e.H)
+	pi := math.Atan2(e.H, e.K) // longitude of perihelion
+	sinHalfI := math.Hypot(e.Q, e.P)
+	om := math.Atan2(e.P, e.Q) // longitude of ascending node
+	inc := 2 * math.Asin(sinHalfI)
+	w := pi - om // argument of perihelion
+
+	ea := kepler(pmod(e.L-pi, 2*math.Pi), ecc)
+	_, cosE := math.Sincos(ea)
+	r := e.A * (1 - ecc*cosE)
+	nu := 2 * math.Atan2(math.Sqrt(1+ecc)*math.Sin(ea/2), math.Sqrt(1-ecc)*math.Cos(ea/2))
+	sinNu, cosNu := math.Sincos(nu)
+
+	p := e.A * (1 - ecc*ecc)
+	speed := math.Sqrt(GM / p)
+
+	xp, yp := r*cosNu, r*sinNu
+	vxp, vyp := -speed*sinNu, speed*(ecc+cosNu)
+
+	px, py, pz = rotatePerifocal(xp, yp, om, inc, w)
+	vx, vy, vz = rotatePerifocal(vxp, vyp, om, inc, w)
+	return
+}
+
+// kepler solves M = E - e sinE for the eccentric anomaly E by
+// Newton-Raphson, starting at E0 = M and iterating until the correction is
+// smaller than 1e-14 or 30 iterations have passed.  For eccentricities
+// above .99, where Newton-Raphson does not reliably converge, it falls
+// back to bisection.
+func kepler(m, ecc float64) float64 {
+	if ecc > .99 {
+		return keplerBisect(m, ecc)
+	}
+	ea := m
+	for i := 0; i < 30; i++ {
+		d := (ea - ecc*math.Sin(ea) - m) / (1 - ecc*math.Cos(ea))
+		ea -= d
+		if math.Abs(d) < 1e-14 {
+			break
+		}
+	}
+	return ea
+}
+
+// keplerBisect solves the same equation as kepler by bisection, for
+// eccentricities too high for Newton-Raphson to reliably converge.
+func keplerBisect(m, ecc float64) float64 {
+	f := func(ea float64) float64 { return ea - ecc*math.Sin(ea) - m }
+	lo, hi := m-math.Pi, m+math.Pi
+	for i := 0; i < 100 && hi-lo > 1e-14; i++ {
+		mid := (lo + hi) / 2
+		if f(mid) > 0 {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// rotatePerifocal rotates a perifocal-frame vector (x, y, 0) into the
+// heliocentric ecliptic frame by the 3-1-3 Euler sequence (Ω, i, ω).
+func rotatePerifocal(x, y, bigOmega, inc, argPeri float64) (px, py, pz float64) {
+	sO, cO := math.Sincos(bigOmega)
+	sI, cI := math.Sincos(inc)
+	sW, cW := math.Sincos(argPeri)
+
+	px = (cO*cW-sO*sW*cI)*x + (-cO*sW-sO*cW*cI)*y
+	py = (sO*cW+cO*sW*cI)*x + (-sO*sW+cO*cW*cI)*y
+	pz = sW*sI*x + cW*sI*y
+	return
+}
+
+// Spherical converts a rectangular position and velocity to heliocentric
+// ecliptic spherical coordinates.
+func (r *Rectangular) Spherical() Spherical {
+	rho2 := r.Px*r.Px + r.Py*r.Py
+	rho := math.Sqrt(rho2)
+	radius := math.Sqrt(rho2 + r.Pz*r.Pz)
+	lon := pmod(math.Atan2(r.Py, r.Px), 2*math.Pi)
+	lat := math.Atan2(r.Pz, rho)
+
+	var vr, vlon, vlat float64
+	if radius != 0 {
+		vr = (r.Px*r.Vx + r.Py*r.Vy + r.Pz*r.Vz) / radius
+	}
+	if rho != 0 {
+		vlon = (r.Px*r.Vy - r.Py*r.Vx) / rho2
+		vlat = (r.Vz*rho2 - r.Pz*(r.Px*r.Vx+r.Py*r.Vy)) / (radius * radius * rho)
+	}
+	return Spherical{lon, lat, radius, vlon, vlat, vr}
+}
@@ -0,0 +1,57 @@
+package vsop87_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/soniakeys/vsop87"
+)
+
+func TestEllipticRectangularCircular(t *testing.T) {
+	e := vsop87.Elliptic{A: 1, L: 1.2}
+	r := e.Rectangular()
+	radius := math.Sqrt(r.Px*r.Px + r.Py*r.Py + r.Pz*r.Pz)
+	if math.Abs(radius-e.A) > 1e-12 {
+		t.Errorf("expected radius %v for a circular, equatorial orbit, got %v", e.A, radius)
+	}
+	// e = i = 0, so the true longitude equals the mean longitude.
+	lon := math.Atan2(r.Py, r.Px)
+	if math.Abs(lon-e.L) > 1e-9 {
+		t.Errorf("expected longitude %v, got %v", e.L, lon)
+	}
+	if r.Pz != 0 {
+		t.Errorf("expected Pz = 0 for an equatorial orbit, got %v", r.Pz)
+	}
+}
+
+func TestEllipticRectangularVisViva(t *testing.T) {
+	e := vsop87.Elliptic{A: 1.5, L: 2.1, K: .05, H: .02, Q: .01, P: -.01}
+	r := e.Rectangular()
+	radius := math.Sqrt(r.Px*r.Px + r.Py*r.Py + r.Pz*r.Pz)
+	speed := math.Sqrt(r.Vx*r.Vx + r.Vy*r.Vy + r.Vz*r.Vz)
+	want := math.Sqrt(vsop87.GM * (2/radius - 1/e.A))
+	if math.Abs(speed-want) > 1e-9*want {
+		t.Errorf("vis-viva check failed: speed %v, want %v", speed, want)
+	}
+}
+
+func TestEllipticSphericalMatchesRectangularSpherical(t *testing.T) {
+	e := vsop87.Elliptic{A: 1.2, L: .7, K: .1, H: -.05, Q: .02, P: .03}
+	s := e.Spherical()
+	r := e.Rectangular()
+	rs := r.Spherical()
+	if s != rs {
+		t.Errorf("Elliptic.Spherical() = %+v, want Rectangular().Spherical() = %+v", s, rs)
+	}
+}
+
+func TestRectangularSphericalHighLatitude(t *testing.T) {
+	r := vsop87.Rectangular{Px: 0, Py: 0, Pz: 2, Vx: 0, Vy: 1, Vz: 0}
+	s := r.Spherical()
+	if math.Abs(s.R-2) > 1e-12 {
+		t.Errorf("expected R = 2, got %v", s.R)
+	}
+	if math.Abs(s.Lat-math.Pi/2) > 1e-12 {
+		t.Errorf("expected Lat = pi/2, got %v", s.Lat)
+	}
+}
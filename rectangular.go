@@ -0,0 +1,84 @@
+package vsop87
+
+import "errors"
+
+type rectangularCoeff struct {
+	x, y, z coeff
+}
+
+// RectangularModel holds parsed VSOP87 coefficients for the A (heliocentric
+// rectangular, J2000), C (heliocentric rectangular, equinox of date), and E
+// (barycentric rectangular, J2000) variants.
+//
+// For variant 'E', only the planet series are loaded; the Sun's own
+// barycentric series (body index Sun) is not read, as nBodies has no
+// extension or body-name entry for it.
+type RectangularModel struct {
+	variant byte
+	t       [6]float64
+	c       [nBodies]rectangularCoeff
+}
+
+// NewRectangularModel reads VSOP87 files for variant 'A', 'C', or 'E' from
+// src and returns an object that can compute positions.
+//
+// Tdj does not have to be exact.  It is used only for determining the
+// subset of coefficients needed for the requested precition.
+func NewRectangularModel(src Source, variant byte, prec, tdj float64) (*RectangularModel, error) {
+	if variant != 'A' && variant != 'C' && variant != 'E' {
+		return nil, errors.New("Invalid variant, must be 'A', 'C', or 'E'.")
+	}
+	q, at, err := precTerms(prec, tdj)
+	if err != nil {
+		return nil, err
+	}
+	rm := &RectangularModel{variant: variant}
+	for _, ibody := range bodies {
+		if err := rm.readBody(src, ibody, q, prec, at); err != nil {
+			return nil, err
+		}
+	}
+	return rm, nil
+}
+
+// NewRectangularModelFromPath reads VSOP87 files from the directory at
+// path.  It is a thin wrapper around
+// NewRectangularModel(DirSource(path), variant, prec, tdj) kept for
+// callers written before the Source abstraction.
+func NewRectangularModelFromPath(path string, variant byte, prec, tdj float64) (*RectangularModel, error) {
+	return NewRectangularModel(DirSource(path), variant, prec, tdj)
+}
+
+func (rm *RectangularModel) readBody(src Source, ibody int, q, prec float64, at []float64) error {
+	rc, err := src.Open(ibody, rm.variant)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	sc := newLineScanner(rc)
+	cb := &rm.c[ibody]
+	if err := cb.x.parse('1', ibody, sc, q, prec, at, true); err != nil {
+		return err
+	}
+	if err := cb.y.parse('2', ibody, sc, q, prec, at, true); err != nil {
+		return err
+	}
+	if err := cb.z.parse('3', ibody, sc, q, prec, at, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Pos computes the rectangular position of ibody at tdj.
+//
+// The velocity fields of r are left zero; RectangularModel only evaluates
+// the position series.  See Elliptic.Rectangular for a position/velocity
+// result derived from elliptic elements.
+func (rm *RectangularModel) Pos(tdj float64, ibody int, r *Rectangular) {
+	powers(&rm.t, tdj)
+	cb := rm.c[ibody]
+	r.Px = cb.x.sum(&rm.t)
+	r.Py = cb.y.sum(&rm.t)
+	r.Pz = cb.z.sum(&rm.t)
+	r.Vx, r.Vy, r.Vz = 0, 0, 0
+}
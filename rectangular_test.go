@@ -0,0 +1,30 @@
+package vsop87_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/soniakeys/vsop87"
+)
+
+func TestRectangularModelPos(t *testing.T) {
+	fsys := fsWithBody('A', 3, 1, 2, vsop87.Mercury)
+	rm, err := vsop87.NewRectangularModel(vsop87.FSSource(fsys), 'A', 1e-7, 2451545)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r vsop87.Rectangular
+	rm.Pos(2451545, vsop87.Mercury, &r)
+	if r.Px != 2 {
+		t.Errorf("expected Px = 2, got %v", r.Px)
+	}
+	if r.Py != 0 || r.Pz != 0 {
+		t.Errorf("expected Py = Pz = 0, got %v, %v", r.Py, r.Pz)
+	}
+}
+
+func TestNewRectangularModelInvalidVariant(t *testing.T) {
+	if _, err := vsop87.NewRectangularModel(vsop87.FSSource(fstest.MapFS{}), 'X', 0, 2451545); err == nil {
+		t.Fatal("expected an error for an invalid variant")
+	}
+}
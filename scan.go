@@ -0,0 +1,49 @@
+package vsop87
+
+import (
+	"bufio"
+	"io"
+)
+
+// lineScanner is a one-line lookahead over an io.Reader, letting
+// coeff.parse peek at a header line to decide whether it belongs to the
+// current block before consuming it.
+type lineScanner struct {
+	sc   *bufio.Scanner
+	line int
+	pend string
+	has  bool
+	done bool
+}
+
+func newLineScanner(r io.Reader) *lineScanner {
+	return &lineScanner{sc: bufio.NewScanner(r)}
+}
+
+// peek returns the next unconsumed line without advancing past it.
+func (s *lineScanner) peek() (string, bool) {
+	if !s.has && !s.done {
+		if s.sc.Scan() {
+			s.pend = s.sc.Text()
+			s.has = true
+			s.line++
+		} else {
+			s.done = true
+		}
+	}
+	return s.pend, s.has
+}
+
+// next returns the next unconsumed line and advances past it.
+func (s *lineScanner) next() (string, bool) {
+	line, ok := s.peek()
+	if ok {
+		s.has = false
+	}
+	return line, ok
+}
+
+// err returns the first non-EOF error encountered while scanning, if any.
+func (s *lineScanner) err() error {
+	return s.sc.Err()
+}
@@ -0,0 +1,55 @@
+package vsop87
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Source supplies the raw VSOP87 coefficient data for one body and
+// variant, letting the model constructors read from a directory, an
+// fs.FS (including one built with //go:embed), or any other source able
+// to produce an io.ReadCloser.
+//
+// Variant is 0 for the elliptic variant (files named "VSOP87.<ext>") or
+// one of 'A' through 'E' for the other variants (files named
+// "VSOP87<variant>.<ext>").
+type Source interface {
+	Open(body int, variant byte) (io.ReadCloser, error)
+}
+
+// coeffFileName returns the VSOP87 file name for body and variant,
+// relative to a Source's root.
+func coeffFileName(body int, variant byte) string {
+	if variant == 0 {
+		return "VSOP87." + ext[body]
+	}
+	return "VSOP87" + string(variant) + "." + ext[body]
+}
+
+type dirSource string
+
+// DirSource returns a Source that reads VSOP87 files from the directory
+// at path.
+func DirSource(path string) Source {
+	return dirSource(path)
+}
+
+func (d dirSource) Open(body int, variant byte) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(string(d), coeffFileName(body, variant)))
+}
+
+type fsSource struct {
+	fsys fs.FS
+}
+
+// FSSource returns a Source that reads VSOP87 files from fsys, for
+// example one built with //go:embed.
+func FSSource(fsys fs.FS) Source {
+	return fsSource{fsys}
+}
+
+func (s fsSource) Open(body int, variant byte) (io.ReadCloser, error) {
+	return s.fsys.Open(coeffFileName(body, variant))
+}
@@ -0,0 +1,46 @@
+package vsop87_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/soniakeys/vsop87"
+)
+
+func TestDirSourceOpen(t *testing.T) {
+	dir := t.TempDir()
+	data := varTermFile("MERCURY", 6, 1, 1)
+	if err := os.WriteFile(filepath.Join(dir, "VSOP87.mer"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := vsop87.DirSource(dir).Open(vsop87.Mercury, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+}
+
+func TestDirSourceOpenMissing(t *testing.T) {
+	if _, err := vsop87.DirSource(t.TempDir()).Open(vsop87.Mercury, 0); err == nil {
+		t.Fatal("expected an error opening a file that doesn't exist")
+	}
+}
+
+func TestFSSourceOpen(t *testing.T) {
+	fsys := fstest.MapFS{
+		"VSOP87B.mer": &fstest.MapFile{Data: varTermFile("MERCURY", 3, 1, 1)},
+	}
+	rc, err := vsop87.FSSource(fsys).Open(vsop87.Mercury, 'B')
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+}
+
+func TestFSSourceOpenMissing(t *testing.T) {
+	if _, err := vsop87.FSSource(fstest.MapFS{}).Open(vsop87.Mercury, 0); err == nil {
+		t.Fatal("expected an error opening a file that doesn't exist")
+	}
+}
@@ -0,0 +1,83 @@
+package vsop87
+
+import (
+	"errors"
+	"math"
+)
+
+type sphericalCoeff struct {
+	l, b, r coeff
+}
+
+// SphericalModel holds parsed VSOP87 coefficients for the B (heliocentric
+// spherical, J2000) and D (heliocentric spherical, equinox of date)
+// variants.
+type SphericalModel struct {
+	variant byte
+	t       [6]float64
+	c       [nBodies]sphericalCoeff
+}
+
+// NewSphericalModel reads VSOP87 files for variant 'B' or 'D' from src and
+// returns an object that can compute positions.
+//
+// Tdj does not have to be exact.  It is used only for determining the
+// subset of coefficients needed for the requested precition.
+func NewSphericalModel(src Source, variant byte, prec, tdj float64) (*SphericalModel, error) {
+	if variant != 'B' && variant != 'D' {
+		return nil, errors.New("Invalid variant, must be 'B' or 'D'.")
+	}
+	q, at, err := precTerms(prec, tdj)
+	if err != nil {
+		return nil, err
+	}
+	sm := &SphericalModel{variant: variant}
+	for _, ibody := range bodies {
+		if err := sm.readBody(src, ibody, q, prec, at); err != nil {
+			return nil, err
+		}
+	}
+	return sm, nil
+}
+
+// NewSphericalModelFromPath reads VSOP87 files from the directory at
+// path.  It is a thin wrapper around
+// NewSphericalModel(DirSource(path), variant, prec, tdj) kept for callers
+// written before the Source abstraction.
+func NewSphericalModelFromPath(path string, variant byte, prec, tdj float64) (*SphericalModel, error) {
+	return NewSphericalModel(DirSource(path), variant, prec, tdj)
+}
+
+func (sm *SphericalModel) readBody(src Source, ibody int, q, prec float64, at []float64) error {
+	rc, err := src.Open(ibody, sm.variant)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	sc := newLineScanner(rc)
+	cb := &sm.c[ibody]
+	if err := cb.l.parse('1', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.b.parse('2', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.r.parse('3', ibody, sc, q, prec, at, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Pos computes the heliocentric spherical position of ibody at tdj.
+//
+// The velocity fields of r are left zero; SphericalModel only evaluates the
+// position series.  See Elliptic.Spherical for a position/velocity
+// result derived from elliptic elements.
+func (sm *SphericalModel) Pos(tdj float64, ibody int, r *Spherical) {
+	powers(&sm.t, tdj)
+	cb := sm.c[ibody]
+	r.Lon = pmod(cb.l.sum(&sm.t), 2*math.Pi)
+	r.Lat = cb.b.sum(&sm.t)
+	r.R = cb.r.sum(&sm.t)
+	r.VLon, r.VLat, r.VR = 0, 0, 0
+}
@@ -0,0 +1,30 @@
+package vsop87_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/soniakeys/vsop87"
+)
+
+func TestSphericalModelPos(t *testing.T) {
+	fsys := fsWithBody('B', 3, 3, 1.25, vsop87.Mercury)
+	sm, err := vsop87.NewSphericalModel(vsop87.FSSource(fsys), 'B', 1e-7, 2451545)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s vsop87.Spherical
+	sm.Pos(2451545, vsop87.Mercury, &s)
+	if s.R != 1.25 {
+		t.Errorf("expected R = 1.25, got %v", s.R)
+	}
+	if s.Lon != 0 || s.Lat != 0 {
+		t.Errorf("expected Lon = Lat = 0, got %v, %v", s.Lon, s.Lat)
+	}
+}
+
+func TestNewSphericalModelInvalidVariant(t *testing.T) {
+	if _, err := vsop87.NewSphericalModel(vsop87.FSSource(fstest.MapFS{}), 'X', 0, 2451545); err == nil {
+		t.Fatal("expected an error for an invalid variant")
+	}
+}
@@ -0,0 +1,96 @@
+package vsop87_test
+
+import (
+	"fmt"
+	"strings"
+	"testing/fstest"
+
+	"github.com/soniakeys/vsop87"
+)
+
+// allBodies lists the bodies a model constructor reads a file for, with
+// the same 7-character body name and file extension vsop87.go uses.
+var allBodies = []struct {
+	body int
+	name string
+	ext  string
+}{
+	{vsop87.Mercury, "MERCURY", "mer"},
+	{vsop87.Venus, "VENUS  ", "ven"},
+	{vsop87.EarthMoon, "EMB    ", "emb"},
+	{vsop87.Mars, "MARS   ", "mar"},
+	{vsop87.Jupiter, "JUPITER", "jup"},
+	{vsop87.Saturn, "SATURN ", "sat"},
+	{vsop87.Uranus, "URANUS ", "ura"},
+	{vsop87.Neptune, "NEPTUNE", "nep"},
+}
+
+// coeffFileName mirrors the unexported function of the same name in
+// source.go, which test files outside the package can't call directly.
+func coeffFileName(ext string, variant byte) string {
+	if variant == 0 {
+		return "VSOP87." + ext
+	}
+	return "VSOP87" + string(variant) + "." + ext
+}
+
+// fsWithBody builds an in-memory VSOP87 file set for variant with nVars
+// variables, giving every body all-zero terms except target, which gets
+// a single constant term a0 on variable varIdx (1-based).
+func fsWithBody(variant byte, nVars, varIdx int, a0 float64, target int) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, b := range allBodies {
+		data := varTermFile(b.name, nVars, 0, 0)
+		if b.body == target {
+			data = varTermFile(b.name, nVars, varIdx, a0)
+		}
+		fsys[coeffFileName(b.ext, variant)] = &fstest.MapFile{Data: data}
+	}
+	return fsys
+}
+
+// vsop87Line builds one 132-column VSOP87 header line for variable ic,
+// body name bodyName (already padded to 7 characters), time power it,
+// and term count n.
+func vsop87Line(ic byte, bodyName string, it byte, n int) string {
+	b := make([]byte, 132)
+	for i := range b {
+		b[i] = ' '
+	}
+	b[41] = ic
+	copy(b[22:29], bodyName)
+	b[59] = it
+	copy(b[60:67], []byte(fmt.Sprintf("%7d", n)))
+	return string(b)
+}
+
+// vsop87Term builds one 132-column VSOP87 coefficient line with amplitude
+// a, phase b (radians), and frequency c (radians/millennium).
+func vsop87Term(a, b, c float64) string {
+	buf := make([]byte, 132)
+	for i := range buf {
+		buf[i] = ' '
+	}
+	copy(buf[79:97], []byte(fmt.Sprintf("%18.11f", a)))
+	copy(buf[98:111], []byte(fmt.Sprintf("%013.10f", b)))
+	copy(buf[111:131], []byte(fmt.Sprintf("%20.11f", c)))
+	return string(buf)
+}
+
+// varTermFile builds a minimal VSOP87 file for one body with nVars
+// variable records ('1'..'0'+nVars).  Variable varIdx (1-based) gets a
+// single constant term of amplitude a0; every other variable gets zero
+// terms, so Pos evaluates to exactly a0 on that variable and 0 elsewhere.
+func varTermFile(bodyName string, nVars, varIdx int, a0 float64) []byte {
+	var lines []string
+	for v := 1; v <= nVars; v++ {
+		ic := byte('0' + v)
+		if v == varIdx {
+			lines = append(lines, vsop87Line(ic, bodyName, '0', 1))
+			lines = append(lines, vsop87Term(a0, 0, 0))
+		} else {
+			lines = append(lines, vsop87Line(ic, bodyName, '0', 0))
+		}
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
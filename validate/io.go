@@ -0,0 +1,136 @@
+//go:build validation
+
+package validate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/soniakeys/vsop87"
+)
+
+// bodyNames maps the body names used in reference dumps to the vsop87
+// body constants.  The Sun has no entry: EllipticModel never parses a
+// series for it (its heliocentric position is trivially the origin), so
+// there is no VSOP87 series for Run to validate against.
+//
+// "EARTH" has no entry either, and is rejected rather than aliased to the
+// Earth-Moon barycenter: the offset between Earth and the EMB is on the
+// order of 4,700 km, which swamps any realistic tolerance, so silently
+// comparing literal DE440 "EARTH" states against the EMB series would
+// produce a guaranteed, meaningless FAIL.  Callers must supply true
+// barycenter states labeled "EARTH-MOON".
+var bodyNames = map[string]int{
+	"MERCURY":    vsop87.Mercury,
+	"VENUS":      vsop87.Venus,
+	"MARS":       vsop87.Mars,
+	"JUPITER":    vsop87.Jupiter,
+	"SATURN":     vsop87.Saturn,
+	"URANUS":     vsop87.Uranus,
+	"NEPTUNE":    vsop87.Neptune,
+	"EARTH-MOON": vsop87.EarthMoon,
+}
+
+// LoadStates reads a reference ephemeris dump from path, a CSV file with
+// header "jd,body,x,y,z,vx,vy,vz" or a JSON array of the same fields, as
+// produced from JPL DE440/DE440s.  The format is chosen by the file
+// extension (".csv" or ".json").
+func LoadStates(path string) ([]State, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return loadStatesJSON(f)
+	case ".csv":
+		return loadStatesCSV(f)
+	default:
+		return nil, fmt.Errorf("validate: unrecognized reference file extension %q", path)
+	}
+}
+
+func loadStatesJSON(r io.Reader) ([]State, error) {
+	var raw []struct {
+		JD         float64 `json:"jd"`
+		Body       string  `json:"body"`
+		X, Y, Z    float64
+		VX, VY, VZ float64
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	states := make([]State, len(raw))
+	for i, s := range raw {
+		b, ok := bodyNames[strings.ToUpper(s.Body)]
+		if !ok {
+			return nil, fmt.Errorf("validate: unrecognized body %q", s.Body)
+		}
+		states[i] = State{s.JD, b, s.X, s.Y, s.Z, s.VX, s.VY, s.VZ}
+	}
+	return states, nil
+}
+
+func loadStatesCSV(r io.Reader) ([]State, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, want := range []string{"jd", "body", "x", "y", "z", "vx", "vy", "vz"} {
+		if _, ok := col[want]; !ok {
+			return nil, fmt.Errorf("validate: missing column %q", want)
+		}
+	}
+
+	var states []State
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		s, err := parseRow(rec, col)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+func parseRow(rec []string, col map[string]int) (State, error) {
+	field := func(name string) (float64, error) {
+		return strconv.ParseFloat(strings.TrimSpace(rec[col[name]]), 64)
+	}
+	jd, err := field("jd")
+	if err != nil {
+		return State{}, err
+	}
+	b, ok := bodyNames[strings.ToUpper(strings.TrimSpace(rec[col["body"]]))]
+	if !ok {
+		return State{}, fmt.Errorf("validate: unrecognized body %q", rec[col["body"]])
+	}
+	var v [6]float64
+	for i, name := range []string{"x", "y", "z", "vx", "vy", "vz"} {
+		f, err := field(name)
+		if err != nil {
+			return State{}, err
+		}
+		v[i] = f
+	}
+	return State{jd, b, v[0], v[1], v[2], v[3], v[4], v[5]}, nil
+}
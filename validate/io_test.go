@@ -0,0 +1,80 @@
+//go:build validation
+
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/soniakeys/vsop87"
+	"github.com/soniakeys/vsop87/validate"
+)
+
+func TestLoadStatesCSV(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "states.csv")
+	csv := "jd,body,x,y,z,vx,vy,vz\n" +
+		"2451545,MERCURY,1,2,3,4,5,6\n" +
+		"2451545,EARTH-MOON,7,8,9,10,11,12\n"
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err := validate.LoadStates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("expected 2 states, got %d", len(states))
+	}
+	if states[0].Body != vsop87.Mercury || states[0].X != 1 || states[0].VZ != 6 {
+		t.Errorf("unexpected first state: %+v", states[0])
+	}
+	if states[1].Body != vsop87.EarthMoon || states[1].Z != 9 {
+		t.Errorf("unexpected second state: %+v", states[1])
+	}
+}
+
+func TestLoadStatesJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "states.json")
+	j := `[{"jd":2451545,"body":"VENUS","X":1,"Y":2,"Z":3,"VX":4,"VY":5,"VZ":6}]`
+	if err := os.WriteFile(path, []byte(j), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err := validate.LoadStates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 1 || states[0].Body != vsop87.Venus || states[0].Y != 2 {
+		t.Errorf("unexpected states: %+v", states)
+	}
+}
+
+func TestLoadStatesRejectsEarth(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "states.csv")
+	csv := "jd,body,x,y,z,vx,vy,vz\n" +
+		"2451545,EARTH,1,2,3,4,5,6\n"
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := validate.LoadStates(path); err == nil {
+		t.Fatal("expected an error loading a state labeled \"EARTH\": it is ambiguous " +
+			"with the Earth-Moon barycenter VSOP87 actually models")
+	}
+}
+
+func TestLoadStatesUnrecognizedExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "states.txt")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validate.LoadStates(path); err == nil {
+		t.Fatal("expected an error for an unrecognized file extension")
+	}
+}
@@ -0,0 +1,82 @@
+//go:build validation
+
+package validate_test
+
+import (
+	"fmt"
+	"strings"
+	"testing/fstest"
+
+	"github.com/soniakeys/vsop87"
+)
+
+// allBodies lists the bodies NewEllipticModel reads a file for, with the
+// same 7-character body name and file extension vsop87.go uses.
+var allBodies = []struct {
+	body int
+	name string
+	ext  string
+}{
+	{vsop87.Mercury, "MERCURY", "mer"},
+	{vsop87.Venus, "VENUS  ", "ven"},
+	{vsop87.EarthMoon, "EMB    ", "emb"},
+	{vsop87.Mars, "MARS   ", "mar"},
+	{vsop87.Jupiter, "JUPITER", "jup"},
+	{vsop87.Saturn, "SATURN ", "sat"},
+	{vsop87.Uranus, "URANUS ", "ura"},
+	{vsop87.Neptune, "NEPTUNE", "nep"},
+}
+
+// vsop87Line builds one 132-column VSOP87 header line.
+func vsop87Line(ic byte, bodyName string, n int) string {
+	b := make([]byte, 132)
+	for i := range b {
+		b[i] = ' '
+	}
+	b[41] = ic
+	copy(b[22:29], bodyName)
+	b[59] = '0'
+	copy(b[60:67], []byte(fmt.Sprintf("%7d", n)))
+	return string(b)
+}
+
+// vsop87Term builds one 132-column VSOP87 coefficient line for a constant
+// term (phase and rate both zero).
+func vsop87Term(a float64) string {
+	buf := make([]byte, 132)
+	for i := range buf {
+		buf[i] = ' '
+	}
+	copy(buf[79:97], []byte(fmt.Sprintf("%18.11f", a)))
+	copy(buf[98:111], []byte(fmt.Sprintf("%013.10f", 0.0)))
+	copy(buf[111:131], []byte(fmt.Sprintf("%20.11f", 0.0)))
+	return string(buf)
+}
+
+// circularOrbitFile builds a VSOP87 elliptic-variant file for one body
+// with semi-major axis a and all other elements zero, i.e. a circular,
+// equatorial orbit at longitude zero.
+func circularOrbitFile(bodyName string, a float64) []byte {
+	lines := []string{
+		vsop87Line('1', bodyName, 1),
+		vsop87Term(a),
+		vsop87Line('2', bodyName, 0),
+		vsop87Line('3', bodyName, 0),
+		vsop87Line('4', bodyName, 0),
+		vsop87Line('5', bodyName, 0),
+		vsop87Line('6', bodyName, 0),
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// newCircularModel builds an EllipticModel where every body is on a
+// circular, equatorial orbit of semi-major axis 1 au at longitude zero,
+// so Rectangular() is the deterministic position (1, 0, 0) and velocity
+// (0, k, 0) au/day, k the Gaussian gravitational constant.
+func newCircularModel() (*vsop87.EllipticModel, error) {
+	fsys := fstest.MapFS{}
+	for _, b := range allBodies {
+		fsys["VSOP87."+b.ext] = &fstest.MapFile{Data: circularOrbitFile(b.name, 1)}
+	}
+	return vsop87.NewEllipticModel(vsop87.FSSource(fsys), 1e-7, 2451545)
+}
@@ -0,0 +1,142 @@
+//go:build validation
+
+// Package validate cross-validates VSOP87 positions and velocities
+// against a reference ephemeris, such as a CSV or JSON dump of JPL
+// DE440/DE440s states.  It is excluded from normal builds by the
+// "validation" build tag since it has no use outside of a regression
+// check against externally supplied reference data.
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/soniakeys/vsop87"
+)
+
+// AU is the astronomical unit, in kilometers (IAU 2012 definition).
+const AU = 149597870.7
+
+// mmPerAuDay converts an au/day velocity difference to mm/s.
+const mmPerAuDay = AU * 1e6 / 86400
+
+// State is one reference ephemeris sample: the TDB Julian date, the body
+// it applies to, and the heliocentric position/velocity in the VSOP87
+// ecliptic J2000 frame, in au and au/day.
+type State struct {
+	JD         float64
+	Body       int
+	X, Y, Z    float64
+	VX, VY, VZ float64
+}
+
+// BodyTolerance gives the maximum acceptable position error (km) and
+// velocity error (mm/s) for one body.
+type BodyTolerance struct {
+	PosKm     float64
+	VelMmPerS float64
+}
+
+// DefaultTolerances gives generous per-body thresholds for VSOP87 vs.
+// DE440 over the +-1000 year span from J2000 where VSOP87 is rated:
+// 10 m for Mercury, Venus and the Earth-Moon barycenter and 20 m for
+// Mars, growing to tens of km for the outer planets.  Callers validating a narrower span, or wanting a tighter
+// regression alert, should supply their own.
+//
+// The Earth entry is keyed by vsop87.EarthMoon, not vsop87.Earth: States
+// loaded by LoadStates never carry vsop87.Earth (see bodyNames), since
+// EllipticModel only has a series for the Earth-Moon barycenter.
+var DefaultTolerances = map[int]BodyTolerance{
+	vsop87.Mercury:   {PosKm: .01, VelMmPerS: .1},
+	vsop87.Venus:     {PosKm: .01, VelMmPerS: .1},
+	vsop87.EarthMoon: {PosKm: .01, VelMmPerS: .1},
+	vsop87.Mars:      {PosKm: .02, VelMmPerS: .2},
+	vsop87.Jupiter:   {PosKm: 1, VelMmPerS: 1},
+	vsop87.Saturn:    {PosKm: 5, VelMmPerS: 2},
+	vsop87.Uranus:    {PosKm: 20, VelMmPerS: 5},
+	vsop87.Neptune:   {PosKm: 30, VelMmPerS: 5},
+}
+
+// BodyReport summarizes the errors found for one body across all
+// reference states supplied for it.
+type BodyReport struct {
+	Body         int
+	N            int
+	MaxPosKm     float64
+	RMSPosKm     float64
+	MaxVelMmPerS float64
+	RMSVelMmPerS float64
+	Tolerance    BodyTolerance
+	Pass         bool
+}
+
+// Run computes the VSOP87 rectangular state for each reference state,
+// via em and the Elliptic.Rectangular conversion, and compares it against
+// the reference.  It returns one BodyReport per body present in states,
+// in order of first appearance.  A body with no entry in tol, or whose
+// error comes out non-finite (for example vsop87.Sun, which em never
+// carries a series for), always reports Pass false rather than silently
+// passing.
+func Run(em *vsop87.EllipticModel, states []State, tol map[int]BodyTolerance) []BodyReport {
+	order := []int{}
+	acc := map[int]*BodyReport{}
+	hasTol := map[int]bool{}
+	nonFinite := map[int]bool{}
+	for _, s := range states {
+		r, ok := acc[s.Body]
+		if !ok {
+			t, ok := tol[s.Body]
+			r = &BodyReport{Body: s.Body, Tolerance: t}
+			hasTol[s.Body] = ok
+			acc[s.Body] = r
+			order = append(order, s.Body)
+		}
+
+		var e vsop87.Elliptic
+		em.Pos(s.JD, s.Body, &e)
+		rec := e.Rectangular()
+
+		dPosKm := math.Sqrt(sq(rec.Px-s.X)+sq(rec.Py-s.Y)+sq(rec.Pz-s.Z)) * AU
+		dVelMmPerS := math.Sqrt(sq(rec.Vx-s.VX)+sq(rec.Vy-s.VY)+sq(rec.Vz-s.VZ)) * mmPerAuDay
+
+		if !math.IsInf(dPosKm, 0) && !math.IsNaN(dPosKm) && !math.IsInf(dVelMmPerS, 0) && !math.IsNaN(dVelMmPerS) {
+			if dPosKm > r.MaxPosKm {
+				r.MaxPosKm = dPosKm
+			}
+			if dVelMmPerS > r.MaxVelMmPerS {
+				r.MaxVelMmPerS = dVelMmPerS
+			}
+			r.RMSPosKm += dPosKm * dPosKm
+			r.RMSVelMmPerS += dVelMmPerS * dVelMmPerS
+		} else {
+			nonFinite[s.Body] = true
+		}
+		r.N++
+	}
+
+	reports := make([]BodyReport, len(order))
+	for i, b := range order {
+		r := acc[b]
+		if r.N > 0 {
+			r.RMSPosKm = math.Sqrt(r.RMSPosKm / float64(r.N))
+			r.RMSVelMmPerS = math.Sqrt(r.RMSVelMmPerS / float64(r.N))
+		}
+		r.Pass = hasTol[b] && !nonFinite[b] &&
+			r.MaxPosKm <= r.Tolerance.PosKm && r.MaxVelMmPerS <= r.Tolerance.VelMmPerS
+		reports[i] = *r
+	}
+	return reports
+}
+
+func sq(x float64) float64 { return x * x }
+
+// String formats r as a one-line summary suitable for a regression alert.
+func (r BodyReport) String() string {
+	status := "PASS"
+	if !r.Pass {
+		status = "FAIL"
+	}
+	return fmt.Sprintf(
+		"body %d: n=%d maxPos=%.4fkm rmsPos=%.4fkm maxVel=%.4fmm/s rmsVel=%.4fmm/s [%s]",
+		r.Body, r.N, r.MaxPosKm, r.RMSPosKm, r.MaxVelMmPerS, r.RMSVelMmPerS, status)
+}
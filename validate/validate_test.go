@@ -0,0 +1,57 @@
+//go:build validation
+
+package validate_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/soniakeys/vsop87"
+	"github.com/soniakeys/vsop87/validate"
+)
+
+func TestRun(t *testing.T) {
+	em, err := newCircularModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const jd = 2451545
+	vy := math.Sqrt(vsop87.GM)
+
+	states := []validate.State{
+		// Matches em exactly: within tolerance.
+		{JD: jd, Body: vsop87.Mercury, X: 1, Y: 0, Z: 0, VX: 0, VY: vy, VZ: 0},
+		// Off by 1 au in X: exceeds tolerance.
+		{JD: jd, Body: vsop87.Venus, X: 2, Y: 0, Z: 0, VX: 0, VY: vy, VZ: 0},
+		// Matches em exactly, but has no entry in tol below.
+		{JD: jd, Body: vsop87.Mars, X: 1, Y: 0, Z: 0, VX: 0, VY: vy, VZ: 0},
+		// em never parses a series for the Sun: Rectangular() on its
+		// zero-valued elements produces a non-finite velocity.
+		{JD: jd, Body: vsop87.Sun, X: 0, Y: 0, Z: 0, VX: 0, VY: 0, VZ: 0},
+	}
+	tol := map[int]validate.BodyTolerance{
+		vsop87.Mercury: {PosKm: 1e-6, VelMmPerS: 1e-6},
+		vsop87.Venus:   {PosKm: .01, VelMmPerS: .1},
+		vsop87.Sun:     {PosKm: 1, VelMmPerS: 1},
+	}
+
+	reports := validate.Run(em, states, tol)
+	got := map[int]validate.BodyReport{}
+	for _, r := range reports {
+		got[r.Body] = r
+	}
+
+	if !got[vsop87.Mercury].Pass {
+		t.Errorf("Mercury: expected Pass, got %+v", got[vsop87.Mercury])
+	}
+	if got[vsop87.Venus].Pass {
+		t.Errorf("Venus: expected !Pass for a 1 au position error, got %+v", got[vsop87.Venus])
+	}
+	if got[vsop87.Mars].Pass {
+		t.Errorf("Mars: expected !Pass with no tolerance entry, got %+v", got[vsop87.Mars])
+	}
+	if got[vsop87.Sun].Pass {
+		t.Errorf("Sun: expected !Pass for a non-finite error, got %+v", got[vsop87.Sun])
+	}
+}
@@ -3,7 +3,6 @@ package vsop87
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"math"
 	"strconv"
 	"strings"
@@ -56,6 +55,13 @@ var (
 	}
 )
 
+// bodies is the set of bodies VSOP87 data files are provided for, in the
+// order the official distribution lists them.  The Sun itself is excluded;
+// it is only present as an origin, not as a body with its own series,
+// except in the barycentric variant E.
+var bodies = []int{Mercury, Venus, EarthMoon, Mars,
+	Jupiter, Saturn, Uranus, Neptune}
+
 // return type
 type Elliptic struct {
 	A float64 // semi-major axis (au)
@@ -101,8 +107,10 @@ type ellipticCoeff struct {
 }
 
 type EllipticModel struct {
-	t [6]float64
-	c [nBodies]ellipticCoeff
+	t    [6]float64
+	c    [nBodies]ellipticCoeff
+	prec float64 // precision requested when the coefficients were parsed
+	tdj  float64 // tdj requested when the coefficients were parsed
 }
 
 const (
@@ -110,95 +118,102 @@ const (
 	a1000 = 365250  // days per Julian millenium
 )
 
-// NewEllipticModel reads VSOP87 files and returns an object that can compute
-// positions.
-//
-// Tdj does not have to be exact.  It is used only for determining the
-// subset of coefficients needed for the requested precition.
-func NewEllipticModel(path string, prec, tdj float64) (*EllipticModel, error) {
+// precTerms validates prec and returns the truncation threshold q and the
+// powers of abs(t) needed by coeff.parse, shared by all of the model
+// constructors.
+func precTerms(prec, tdj float64) (q float64, at []float64, err error) {
 	if prec < 0 || prec > .01 {
-		return nil, errors.New("Invalid precision.")
+		return 0, nil, errors.New("Invalid precision.")
 	}
-	q := -math.Log10(prec + 1e-50)
+	q = -math.Log10(prec + 1e-50)
 	if q < 3 {
 		q = 3
 	}
-	at := make([]float64, 6) // powers of abs(t)
+	at = make([]float64, 6) // powers of abs(t)
 	at[0] = 1
 	t := math.Abs(tdj-t2000) / a1000
 	for i := 1; i < 6; i++ {
 		at[i] = t * at[i-1]
 	}
-	em := &EllipticModel{}
-	for _, ibody := range []int{Mercury, Venus, EarthMoon, Mars,
-		Jupiter, Saturn, Uranus, Neptune} {
-		data, err := ioutil.ReadFile(path + "/VSOP87." + ext[ibody])
-		if err != nil {
-			return nil, err
-		}
-		lines := strings.Split(string(data), "\n")
+	return q, at, nil
+}
 
-		n := 0
-		n, err = em.c[ibody].a.parse('1', ibody, lines, n, q, prec, at, true)
-		if err != nil {
-			return nil, err
-		}
-		n, err = em.c[ibody].l.parse('2', ibody, lines, n, q, prec, at, false)
-		if err != nil {
-			return nil, err
-		}
-		n, err = em.c[ibody].k.parse('3', ibody, lines, n, q, prec, at, false)
-		if err != nil {
-			return nil, err
-		}
-		n, err = em.c[ibody].h.parse('4', ibody, lines, n, q, prec, at, false)
-		if err != nil {
-			return nil, err
-		}
-		n, err = em.c[ibody].q.parse('5', ibody, lines, n, q, prec, at, false)
-		if err != nil {
-			return nil, err
-		}
-		n, err = em.c[ibody].p.parse('6', ibody, lines, n, q, prec, at, false)
-		if err != nil {
+// NewEllipticModel reads VSOP87 files from src and returns an object that
+// can compute positions.
+//
+// Tdj does not have to be exact.  It is used only for determining the
+// subset of coefficients needed for the requested precition.
+func NewEllipticModel(src Source, prec, tdj float64) (*EllipticModel, error) {
+	q, at, err := precTerms(prec, tdj)
+	if err != nil {
+		return nil, err
+	}
+	em := &EllipticModel{prec: prec, tdj: tdj}
+	for _, ibody := range bodies {
+		if err := em.readBody(src, ibody, q, prec, at); err != nil {
 			return nil, err
 		}
 	}
 	return em, nil
 }
 
-func (c *coeff) parse(ic byte, ibody int, lines []string, n int, q, prec float64, at []float64, au bool) (int, error) {
+// NewEllipticModelFromPath reads VSOP87 files from the directory at path.
+// It is a thin wrapper around NewEllipticModel(DirSource(path), prec, tdj)
+// kept for callers written before the Source abstraction.
+func NewEllipticModelFromPath(path string, prec, tdj float64) (*EllipticModel, error) {
+	return NewEllipticModel(DirSource(path), prec, tdj)
+}
+
+func (em *EllipticModel) readBody(src Source, ibody int, q, prec float64, at []float64) error {
+	rc, err := src.Open(ibody, 0)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	sc := newLineScanner(rc)
+	cb := &em.c[ibody]
+	if err := cb.a.parse('1', ibody, sc, q, prec, at, true); err != nil {
+		return err
+	}
+	if err := cb.l.parse('2', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.k.parse('3', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.h.parse('4', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.q.parse('5', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	if err := cb.p.parse('6', ibody, sc, q, prec, at, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *coeff) parse(ic byte, ibody int, sc *lineScanner, q, prec float64, at []float64, au bool) error {
 	dl := 0.
 	var cbuf [2047]abc
-	for n < len(lines) {
-		line := lines[n]
-		if len(line) < 132 {
-			break
-		}
-		if line[41] != ic {
-			break
+	for {
+		line, ok := sc.peek()
+		if !ok || len(line) < 132 || line[41] != ic {
+			return sc.err()
 		}
-		/*
-			if iv := line[17]; iv != '0' {
-				return n, fmt.Errorf("Line %d: expected version 0, "+
-					"found %c.", n+1, iv)
-			}
-		*/
 		if bo := line[22:29]; bo != b7[ibody] {
-			return n, fmt.Errorf("Line %d: expected body %s, "+
-				"found %s.", n+1, b7[ibody], bo)
+			return fmt.Errorf("Line %d: expected body %s, found %s.",
+				sc.line, b7[ibody], bo)
 		}
 		it := line[59] - '0'
 		in, err := strconv.Atoi(strings.TrimSpace(line[60:67]))
 		if err != nil {
-			return n, fmt.Errorf("Line %d: %v.", n+1, err)
+			return fmt.Errorf("Line %d: %v.", sc.line, err)
 		}
+		sc.next() // consume the header line just peeked at
 		if in == 0 {
 			continue
 		}
-		if in > len(lines)-n {
-			return n, errors.New("Unexpected end of file.")
-		}
 		d0 := at[it]
 		p := prec / 10 / (q - 2) / (d0 + float64(it)*dl*1e-4 + 1e-50)
 		if au {
@@ -206,37 +221,40 @@ func (c *coeff) parse(ic byte, ibody int, lines []string, n int, q, prec float64
 		}
 		dl = d0
 
-		n++
 		cx := 0
-		for _, line := range lines[n : n+in] {
+		truncated := false
+		for i := 0; i < in; i++ {
+			line, ok := sc.next()
+			if !ok {
+				if err := sc.err(); err != nil {
+					return err
+				}
+				return errors.New("Unexpected end of file.")
+			}
+			if truncated {
+				continue
+			}
 			a := &cbuf[cx]
-			a.a, err =
-				strconv.ParseFloat(strings.TrimSpace(line[79:97]), 64)
+			a.a, err = strconv.ParseFloat(strings.TrimSpace(line[79:97]), 64)
 			if err != nil {
-				goto parseError
+				return fmt.Errorf("Line %d: %v.", sc.line, err)
 			}
 			if math.Abs(a.a) < p {
-				fmt.Println("truncated")
-				break
+				truncated = true
+				continue
 			}
 			a.b, err = strconv.ParseFloat(line[98:111], 64)
 			if err != nil {
-				goto parseError
+				return fmt.Errorf("Line %d: %v.", sc.line, err)
 			}
-			a.c, err =
-				strconv.ParseFloat(strings.TrimSpace(line[111:131]), 64)
+			a.c, err = strconv.ParseFloat(strings.TrimSpace(line[111:131]), 64)
 			if err != nil {
-				goto parseError
+				return fmt.Errorf("Line %d: %v.", sc.line, err)
 			}
 			cx++
-			continue
-		parseError:
-			return n, fmt.Errorf("Line %d: %v.", n+cx+1, err)
 		}
 		c[it] = append([]abc{}, cbuf[:cx]...)
-		n += in
 	}
-	return n, nil
 }
 
 func (c *coeff) sum(ts *[6]float64) (r float64) {
@@ -250,12 +268,18 @@ func (c *coeff) sum(ts *[6]float64) (r float64) {
 	return
 }
 
-func (em *EllipticModel) Pos(tdj float64, ibody int, r *Elliptic) {
-	em.t[0] = 1
+// powers sets ts to the powers of t (Julian millennia from J2000) needed to
+// evaluate a coeff.sum, as used by the Pos method of each model type.
+func powers(ts *[6]float64, tdj float64) {
+	ts[0] = 1
 	t := (tdj - t2000) / a1000
 	for i := 1; i < 6; i++ {
-		em.t[i] = t * em.t[i-1]
+		ts[i] = t * ts[i-1]
 	}
+}
+
+func (em *EllipticModel) Pos(tdj float64, ibody int, r *Elliptic) {
+	powers(&em.t, tdj)
 	cb := em.c[ibody]
 	r.A = cb.a.sum(&em.t)
 	r.L = pmod(cb.l.sum(&em.t), 2*math.Pi)
@@ -33,7 +33,7 @@ func TestChk(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	em, err := vsop87.NewEllipticModel(vpath, 0, 2451545)
+	em, err := vsop87.NewEllipticModelFromPath(vpath, 0, 2451545)
 	if err != nil {
 		t.Fatal(err)
 	}